@@ -0,0 +1,140 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Client is an OpenAI API client. Create one with NewClient or
+// NewClientWithConfig.
+type Client struct {
+	config ClientConfig
+
+	rateLimitMu sync.RWMutex
+	rateLimit   RateLimitInfo
+}
+
+// NewClient creates a Client for the public OpenAI API, applying any
+// ClientOptions on top of DefaultConfig.
+func NewClient(authToken string, opts ...ClientOption) *Client {
+	config := DefaultConfig(authToken)
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return NewClientWithConfig(config)
+}
+
+// NewClientWithConfig creates a Client from an already built ClientConfig,
+// e.g. one returned by an Azure-specific config constructor.
+func NewClientWithConfig(config ClientConfig) *Client {
+	return &Client{config: config}
+}
+
+// sendRequest sends req, decoding a successful JSON body into v (if v is
+// non-nil), and retries according to c.config.RetryPolicy when the response
+// is a retryable failure. It is the request path used by the idempotent,
+// non-streaming endpoints (Completions, Embeddings, Moderations, file
+// uploads).
+func (c *Client) sendRequest(req *http.Request, v any) error {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		req.Body.Close()
+		body = b
+	}
+
+	policy := c.config.RetryPolicy
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		err := c.doRequest(req, v)
+		if err == nil || attempt >= policy.MaxRetries || !shouldRetry(err, policy) {
+			return err
+		}
+
+		delay := retryBackoff(attempt, httpRetryAfter(err), policy)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+		time.Sleep(delay)
+	}
+}
+
+// doRequest performs a single attempt of req and decodes the response,
+// returning an *APIError or *RequestError when the request fails or the
+// server answers with an error status, or the JSON decoding error when a
+// successful body cannot be decoded into v.
+func (c *Client) doRequest(req *http.Request, v any) error {
+	httpClient := c.config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return &RequestError{Err: err}
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return &RequestError{HTTPStatusCode: res.StatusCode, Err: err}
+	}
+
+	rateLimit := newRateLimitInfo(res.Header)
+	c.rateLimitMu.Lock()
+	c.rateLimit = rateLimit
+	c.rateLimitMu.Unlock()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		decoder := c.config.ErrorDecoder
+		if decoder == nil {
+			decoder = defaultErrorDecoder
+		}
+
+		apiErr, err := decoder.DecodeError(res.StatusCode, resBody)
+		if err != nil {
+			return &RequestError{
+				HTTPStatusCode: res.StatusCode,
+				HTTPRetryAfter: res.Header.Get("Retry-After"),
+				RateLimit:      rateLimit,
+				Err:            errors.New(string(resBody)),
+			}
+		}
+		apiErr.HTTPStatusCode = res.StatusCode
+		apiErr.HTTPRetryAfter = res.Header.Get("Retry-After")
+		apiErr.RateLimit = rateLimit
+		return apiErr
+	}
+
+	if v == nil || len(resBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resBody, v)
+}
+
+// httpRetryAfter returns the Retry-After header value carried by err, if err
+// is an APIError or RequestError.
+func httpRetryAfter(err error) string {
+	apiErr := new(APIError)
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPRetryAfter
+	}
+
+	reqErr := new(RequestError)
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPRetryAfter
+	}
+
+	return ""
+}
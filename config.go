@@ -0,0 +1,57 @@
+package openai
+
+import "net/http"
+
+// openaiAPIURLv1 is the default BaseURL used by DefaultConfig.
+const openaiAPIURLv1 = "https://api.openai.com/v1"
+
+// ClientConfig carries the settings applied by ClientOption functions. Build
+// one with DefaultConfig and NewClientWithConfig, or pass ClientOptions to
+// NewClient.
+type ClientConfig struct {
+	authToken string
+
+	BaseURL    string
+	OrgID      string
+	HTTPClient *http.Client
+
+	RetryPolicy  RetryPolicy
+	ErrorDecoder ErrorDecoder
+}
+
+// DefaultConfig returns the ClientConfig used by NewClient: the public
+// OpenAI API, retries disabled, and the default ErrorDecoder.
+func DefaultConfig(authToken string) ClientConfig {
+	return ClientConfig{
+		authToken:    authToken,
+		BaseURL:      openaiAPIURLv1,
+		HTTPClient:   &http.Client{},
+		RetryPolicy:  defaultRetryPolicy,
+		ErrorDecoder: defaultErrorDecoder,
+	}
+}
+
+// ClientOption configures a ClientConfig. Pass one or more to NewClient.
+type ClientOption func(*ClientConfig)
+
+// WithBaseURL overrides the API base URL, e.g. to target Azure OpenAI or an
+// OpenAI-compatible backend.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *ClientConfig) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithOrgID sets the OpenAI-Organization header sent with every request.
+func WithOrgID(orgID string) ClientOption {
+	return func(c *ClientConfig) {
+		c.OrgID = orgID
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used to send requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *ClientConfig) {
+		c.HTTPClient = httpClient
+	}
+}
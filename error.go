@@ -39,13 +39,14 @@ func IsTooManyRequests(err error) (is429 bool, retryAfter string) {
 // APIError provides error information returned by the OpenAI API.
 // InnerError struct is only valid for Azure OpenAI Service.
 type APIError struct {
-	Code           any         `json:"code,omitempty"`
-	Message        string      `json:"message"`
-	Param          *string     `json:"param,omitempty"`
-	Type           string      `json:"type"`
-	HTTPStatusCode int         `json:"-"`
-	HTTPRetryAfter string      `json:"-"`
-	InnerError     *InnerError `json:"innererror,omitempty"`
+	Code           any           `json:"code,omitempty"`
+	Message        string        `json:"message"`
+	Param          *string       `json:"param,omitempty"`
+	Type           string        `json:"type"`
+	HTTPStatusCode int           `json:"-"`
+	HTTPRetryAfter string        `json:"-"`
+	InnerError     *InnerError   `json:"innererror,omitempty"`
+	RateLimit      RateLimitInfo `json:"-"`
 }
 
 // InnerError Azure Content filtering. Only valid for Azure OpenAI Service.
@@ -54,13 +55,35 @@ type InnerError struct {
 	ContentFilterResults ContentFilterResults `json:"content_filter_result,omitempty"`
 }
 
+// ContentFilterResult is Azure OpenAI's content-filter verdict for a single
+// category.
+type ContentFilterResult struct {
+	Filtered bool   `json:"filtered"`
+	Severity string `json:"severity,omitempty"`
+}
+
+// ContentFilterResults is Azure OpenAI's per-category content filter
+// verdict, attached to APIError via InnerError.
+type ContentFilterResults struct {
+	Hate     ContentFilterResult `json:"hate,omitempty"`
+	SelfHarm ContentFilterResult `json:"self_harm,omitempty"`
+	Sexual   ContentFilterResult `json:"sexual,omitempty"`
+	Violence ContentFilterResult `json:"violence,omitempty"`
+}
+
 // RequestError provides informations about generic request errors.
 type RequestError struct {
 	HTTPStatusCode int
 	HTTPRetryAfter string
+	RateLimit      RateLimitInfo
 	Err            error
 }
 
+// ErrorResponse is OpenAI and Azure OpenAI's {"error": {...}} envelope, the
+// first shape the default ErrorDecoder tries. Clients talking to
+// OpenAI-compatible backends whose envelopes the default ErrorDecoder does
+// not understand should install a different ErrorDecoder via
+// WithErrorDecoder rather than relying on this type directly.
 type ErrorResponse struct {
 	Error *APIError `json:"error,omitempty"`
 }
@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// errDecodeFailed is returned internally by an ErrorDecoder when the
+// response body does not match the shape it knows how to parse.
+var errDecodeFailed = errors.New("openai: unable to decode error response")
+
+// ErrorDecoder decodes a non-2xx HTTP response body into an APIError. The
+// default decoder understands OpenAI's and Azure OpenAI's {"error": {...}}
+// envelope and falls back to the {"code": ..., "msg": "..."} envelope used by
+// Zhipu GLM v4 and Tencent Hunyuan; install a different one with
+// WithErrorDecoder to talk to OpenAI-compatible backends (vLLM, Ollama,
+// FastGPT, ...) whose error envelopes differ from both.
+type ErrorDecoder interface {
+	DecodeError(statusCode int, body []byte) (*APIError, error)
+}
+
+// WithErrorDecoder overrides the ErrorDecoder used to parse non-2xx response
+// bodies. The built-in decoders populate Message, and Code when the body
+// carries one; the Client sets HTTPStatusCode on every decoded APIError, so
+// GetErrHTTPStatus and IsTooManyRequests keep working regardless of which
+// decoder produced the error.
+func WithErrorDecoder(decoder ErrorDecoder) ClientOption {
+	return func(c *ClientConfig) {
+		c.ErrorDecoder = decoder
+	}
+}
+
+// openAIErrorDecoder decodes the standard {"error": {...}} envelope used by
+// OpenAI, and by Azure OpenAI via APIError.InnerError.
+type openAIErrorDecoder struct{}
+
+func (openAIErrorDecoder) DecodeError(statusCode int, body []byte) (*APIError, error) {
+	var errRes ErrorResponse
+	if err := json.Unmarshal(body, &errRes); err != nil || errRes.Error == nil {
+		return nil, errDecodeFailed
+	}
+	errRes.Error.HTTPStatusCode = statusCode
+	return errRes.Error, nil
+}
+
+// codeMsgErrorDecoder decodes the {"code": ..., "msg": "..."} envelope used
+// by several OpenAI-compatible Chinese-vendor backends, e.g. Zhipu GLM v4
+// and Tencent Hunyuan.
+type codeMsgErrorDecoder struct{}
+
+func (codeMsgErrorDecoder) DecodeError(statusCode int, body []byte) (*APIError, error) {
+	var raw struct {
+		Code any    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || raw.Msg == "" {
+		return nil, errDecodeFailed
+	}
+	return &APIError{Code: raw.Code, Message: raw.Msg, HTTPStatusCode: statusCode}, nil
+}
+
+// chainErrorDecoder tries each decoder in turn and returns the first
+// successful decode.
+type chainErrorDecoder struct {
+	decoders []ErrorDecoder
+}
+
+func (c chainErrorDecoder) DecodeError(statusCode int, body []byte) (*APIError, error) {
+	for _, decoder := range c.decoders {
+		if apiErr, err := decoder.DecodeError(statusCode, body); err == nil {
+			return apiErr, nil
+		}
+	}
+	return nil, errDecodeFailed
+}
+
+// defaultErrorDecoder is used by a Client created without WithErrorDecoder.
+var defaultErrorDecoder ErrorDecoder = chainErrorDecoder{
+	decoders: []ErrorDecoder{openAIErrorDecoder{}, codeMsgErrorDecoder{}},
+}
@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAIErrorDecoder(t *testing.T) {
+	body := []byte(`{"error":{"message":"bad param","type":"invalid_request_error"}}`)
+	apiErr, err := openAIErrorDecoder{}.DecodeError(http.StatusBadRequest, body)
+	if err != nil {
+		t.Fatalf("DecodeError: %v", err)
+	}
+	if apiErr.Message != "bad param" || apiErr.Type != "invalid_request_error" {
+		t.Errorf("got %+v", apiErr)
+	}
+}
+
+func TestCodeMsgErrorDecoder(t *testing.T) {
+	body := []byte(`{"code":"1234","msg":"invalid api key","data":null}`)
+	apiErr, err := codeMsgErrorDecoder{}.DecodeError(http.StatusUnauthorized, body)
+	if err != nil {
+		t.Fatalf("DecodeError: %v", err)
+	}
+	if apiErr.Message != "invalid api key" || apiErr.Code != "1234" {
+		t.Errorf("got %+v", apiErr)
+	}
+}
+
+func TestChainErrorDecoderFallsBackAcrossShapes(t *testing.T) {
+	zhipuBody := []byte(`{"code":"1234","msg":"zhipu says no"}`)
+	apiErr, err := defaultErrorDecoder.DecodeError(http.StatusBadRequest, zhipuBody)
+	if err != nil {
+		t.Fatalf("DecodeError: %v", err)
+	}
+	if apiErr.Message != "zhipu says no" {
+		t.Errorf("got %+v, want message from code/msg shape", apiErr)
+	}
+
+	openAIBody := []byte(`{"error":{"message":"rate limited","type":"rate_limit_exceeded"}}`)
+	apiErr, err = defaultErrorDecoder.DecodeError(http.StatusTooManyRequests, openAIBody)
+	if err != nil {
+		t.Fatalf("DecodeError: %v", err)
+	}
+	if apiErr.Message != "rate limited" {
+		t.Errorf("got %+v, want message from openai shape", apiErr)
+	}
+}
+
+func TestClientUsesConfiguredErrorDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"1213","msg":"zhipu shaped failure"}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test-token")
+	config.BaseURL = server.URL
+	config.ErrorDecoder = codeMsgErrorDecoder{}
+	client := NewClientWithConfig(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	err = client.sendRequest(req, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	apiErr := new(APIError)
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Message != "zhipu shaped failure" {
+		t.Errorf("Message = %q, want %q", apiErr.Message, "zhipu shaped failure")
+	}
+}
@@ -0,0 +1,98 @@
+package openai
+
+import "errors"
+
+// Sentinel errors that callers can match against APIError values with
+// errors.Is, e.g. `if errors.Is(err, openai.ErrContextLengthExceeded)`.
+// APIError.Is maps the OpenAI "type"/"code" strings (and, for Azure, the
+// InnerError.Code) onto these.
+var (
+	ErrRateLimited           error = newSentinelError("rate limited")
+	ErrInvalidRequest        error = newSentinelError("invalid request")
+	ErrContextLengthExceeded error = newSentinelError("context length exceeded")
+	ErrContentFilter         error = newSentinelError("content filtered")
+	ErrInsufficientQuota     error = newSentinelError("insufficient quota")
+	ErrModelNotFound         error = newSentinelError("model not found")
+	ErrAuthentication        error = newSentinelError("authentication error")
+	ErrServerOverloaded      error = newSentinelError("server overloaded")
+)
+
+// sentinelError is a distinct error type so that the sentinels above are
+// never accidentally equal to one another or to an unrelated error.
+type sentinelError struct {
+	msg string
+}
+
+func newSentinelError(msg string) *sentinelError {
+	return &sentinelError{msg: msg}
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+// Is implements the errors.Is interface for APIError, mapping the
+// OpenAI-documented type/code strings, and the Azure InnerError.Code, onto
+// the sentinel errors declared above.
+func (e *APIError) Is(target error) bool {
+	return e.sentinel() == target
+}
+
+// sentinel classifies e into one of the package sentinel errors, or nil if
+// none apply.
+func (e *APIError) sentinel() error {
+	if e.InnerError != nil {
+		switch e.InnerError.Code {
+		case "content_filter", "jailbreak":
+			return ErrContentFilter
+		}
+	}
+
+	switch e.Type {
+	case "rate_limit_exceeded":
+		return ErrRateLimited
+	case "invalid_request_error":
+		return ErrInvalidRequest
+	case "context_length_exceeded":
+		return ErrContextLengthExceeded
+	case "content_filter":
+		return ErrContentFilter
+	case "insufficient_quota":
+		return ErrInsufficientQuota
+	case "model_not_found":
+		return ErrModelNotFound
+	case "authentication_error", "invalid_api_key":
+		return ErrAuthentication
+	case "server_error":
+		return ErrServerOverloaded
+	}
+
+	if code, ok := e.Code.(string); ok {
+		switch code {
+		case "context_length_exceeded":
+			return ErrContextLengthExceeded
+		case "insufficient_quota":
+			return ErrInsufficientQuota
+		case "model_not_found":
+			return ErrModelNotFound
+		case "invalid_api_key":
+			return ErrAuthentication
+		}
+	}
+
+	return nil
+}
+
+// AsContentFilterError reports whether err is an APIError whose InnerError
+// carries Azure content filter results, returning them directly instead of
+// requiring callers to reach into InnerError themselves.
+func AsContentFilterError(err error) (*ContentFilterResults, bool) {
+	apiErr := new(APIError)
+	if !errors.As(err, &apiErr) {
+		return nil, false
+	}
+	if apiErr.InnerError == nil {
+		return nil, false
+	}
+	return &apiErr.InnerError.ContentFilterResults, true
+}
@@ -0,0 +1,57 @@
+package openai
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{"context length", &APIError{Type: "context_length_exceeded"}, ErrContextLengthExceeded, true},
+		{"rate limit", &APIError{Type: "rate_limit_exceeded"}, ErrRateLimited, true},
+		{"invalid request", &APIError{Type: "invalid_request_error"}, ErrInvalidRequest, true},
+		{"azure content filter", &APIError{InnerError: &InnerError{Code: "content_filter"}}, ErrContentFilter, true},
+		{"azure jailbreak", &APIError{InnerError: &InnerError{Code: "jailbreak"}}, ErrContentFilter, true},
+		{"code fallback", &APIError{Code: "model_not_found"}, ErrModelNotFound, true},
+		{"mismatch", &APIError{Type: "rate_limit_exceeded"}, ErrInvalidRequest, false},
+		{"no classification", &APIError{Type: "something_else"}, ErrInvalidRequest, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := errors.Is(tc.err, tc.target); got != tc.want {
+				t.Errorf("errors.Is(%+v, target) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAsContentFilterError(t *testing.T) {
+	err := &APIError{
+		InnerError: &InnerError{
+			Code:                 "content_filter",
+			ContentFilterResults: ContentFilterResults{},
+		},
+	}
+
+	results, ok := AsContentFilterError(err)
+	if !ok {
+		t.Fatal("AsContentFilterError ok = false, want true")
+	}
+	if results == nil {
+		t.Fatal("AsContentFilterError results = nil, want non-nil")
+	}
+
+	if _, ok := AsContentFilterError(errors.New("unrelated")); ok {
+		t.Error("AsContentFilterError(unrelated) ok = true, want false")
+	}
+
+	if _, ok := AsContentFilterError(&APIError{}); ok {
+		t.Error("AsContentFilterError(no inner error) ok = true, want false")
+	}
+}
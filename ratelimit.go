@@ -0,0 +1,71 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// RateLimitInfo holds the x-ratelimit-* headers returned on a response,
+// success or error.
+type RateLimitInfo struct {
+	LimitRequests     int
+	LimitTokens       int
+	RemainingRequests int
+	RemainingTokens   int
+	ResetRequests     string
+	ResetTokens       string
+}
+
+// newRateLimitInfo builds a RateLimitInfo from the response headers of an
+// HTTP call to the OpenAI API.
+func newRateLimitInfo(h http.Header) RateLimitInfo {
+	return RateLimitInfo{
+		LimitRequests:     atoiOrZero(h.Get("x-ratelimit-limit-requests")),
+		LimitTokens:       atoiOrZero(h.Get("x-ratelimit-limit-tokens")),
+		RemainingRequests: atoiOrZero(h.Get("x-ratelimit-remaining-requests")),
+		RemainingTokens:   atoiOrZero(h.Get("x-ratelimit-remaining-tokens")),
+		ResetRequests:     h.Get("x-ratelimit-reset-requests"),
+		ResetTokens:       h.Get("x-ratelimit-reset-tokens"),
+	}
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// LastRateLimit returns the RateLimitInfo parsed from the most recent
+// response the Client received. It returns the zero value if no request has
+// completed yet.
+func (c *Client) LastRateLimit() RateLimitInfo {
+	c.rateLimitMu.RLock()
+	defer c.rateLimitMu.RUnlock()
+	return c.rateLimit
+}
+
+// IsQuotaExhausted reports whether err is an APIError caused by the account
+// running out of quota (as opposed to a transient rate limit that will clear
+// once RateLimitInfo.ResetRequests/ResetTokens elapses).
+func IsQuotaExhausted(err error) bool {
+	return errors.Is(err, ErrInsufficientQuota)
+}
+
+// RateLimitFromError extracts the RateLimitInfo attached to err, if err is an
+// APIError or RequestError produced by this package.
+func RateLimitFromError(err error) (RateLimitInfo, bool) {
+	apiErr := new(APIError)
+	if errors.As(err, &apiErr) {
+		return apiErr.RateLimit, true
+	}
+
+	reqErr := new(RequestError)
+	if errors.As(err, &reqErr) {
+		return reqErr.RateLimit, true
+	}
+
+	return RateLimitInfo{}, false
+}
@@ -0,0 +1,100 @@
+package openai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRateLimitInfo(t *testing.T) {
+	h := http.Header{}
+	h.Set("x-ratelimit-limit-requests", "60")
+	h.Set("x-ratelimit-remaining-requests", "59")
+	h.Set("x-ratelimit-limit-tokens", "150000")
+	h.Set("x-ratelimit-remaining-tokens", "149984")
+	h.Set("x-ratelimit-reset-requests", "1s")
+	h.Set("x-ratelimit-reset-tokens", "6m0s")
+
+	got := newRateLimitInfo(h)
+	want := RateLimitInfo{
+		LimitRequests:     60,
+		RemainingRequests: 59,
+		LimitTokens:       150000,
+		RemainingTokens:   149984,
+		ResetRequests:     "1s",
+		ResetTokens:       "6m0s",
+	}
+	if got != want {
+		t.Errorf("newRateLimitInfo = %+v, want %+v", got, want)
+	}
+}
+
+func TestClientLastRateLimitPopulatedOnSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ratelimit-limit-requests", "60")
+		w.Header().Set("x-ratelimit-remaining-requests", "42")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test-token")
+	config.BaseURL = server.URL
+	client := NewClientWithConfig(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := client.sendRequest(req, &struct{}{}); err != nil {
+		t.Fatalf("sendRequest: %v", err)
+	}
+
+	got := client.LastRateLimit()
+	if got.LimitRequests != 60 || got.RemainingRequests != 42 {
+		t.Errorf("LastRateLimit() = %+v, want LimitRequests=60 RemainingRequests=42", got)
+	}
+}
+
+func TestRateLimitFromErrorPopulatedOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ratelimit-limit-requests", "60")
+		w.Header().Set("x-ratelimit-remaining-requests", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"rate limited","type":"rate_limit_exceeded"}}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test-token")
+	config.BaseURL = server.URL
+	client := NewClientWithConfig(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	err = client.sendRequest(req, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	info, ok := RateLimitFromError(err)
+	if !ok {
+		t.Fatal("RateLimitFromError ok = false, want true")
+	}
+	if info.LimitRequests != 60 {
+		t.Errorf("LimitRequests = %d, want 60", info.LimitRequests)
+	}
+	if info.RemainingRequests != 0 {
+		t.Errorf("RemainingRequests = %d, want 0", info.RemainingRequests)
+	}
+}
+
+func TestRateLimitFromErrorUnrelatedError(t *testing.T) {
+	if _, ok := RateLimitFromError(errNotAPIError); ok {
+		t.Error("RateLimitFromError(unrelated error) ok = true, want false")
+	}
+}
+
+var errNotAPIError = &sentinelError{msg: "not an api error"}
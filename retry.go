@@ -0,0 +1,126 @@
+package openai
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the Client retries idempotent requests that fail
+// with a retryable APIError or RequestError. The zero value disables retries;
+// use WithRetryPolicy to install a non-trivial policy on a Client.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of additional attempts after the
+	// initial request. A value of 0 disables retries.
+	MaxRetries int
+
+	// BaseDelay is the starting delay used by the exponential backoff.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff, including jitter.
+	MaxDelay time.Duration
+
+	// RetryQuotaErrors opts into retrying insufficient_quota errors, which
+	// are not retried by default since they rarely resolve on their own.
+	RetryQuotaErrors bool
+
+	// OnRetry, when set, is invoked before each retry sleep with the zero
+	// based attempt number and the error that triggered the retry. It is
+	// useful for logging and metrics.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// defaultRetryPolicy is the policy applied when a Client is created without
+// WithRetryPolicy. Retries are disabled by default so existing callers keep
+// their current behavior.
+var defaultRetryPolicy = RetryPolicy{}
+
+// WithRetryPolicy sets the RetryPolicy used for idempotent requests
+// (Completions, Embeddings, Moderations, and file uploads).
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *ClientConfig) {
+		c.RetryPolicy = policy
+	}
+}
+
+// shouldRetry reports whether err is safe to retry under policy, based on
+// the classification of the underlying APIError/RequestError.
+func shouldRetry(err error, policy RetryPolicy) bool {
+	apiErr := new(APIError)
+	if errors.As(err, &apiErr) {
+		switch apiErr.Type {
+		case "invalid_request_error":
+			return false
+		case "insufficient_quota":
+			return policy.RetryQuotaErrors
+		case "rate_limit_exceeded", "server_error":
+			return true
+		}
+		return apiErr.HTTPStatusCode == http.StatusTooManyRequests || apiErr.HTTPStatusCode >= http.StatusInternalServerError
+	}
+
+	reqErr := new(RequestError)
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode == http.StatusTooManyRequests || reqErr.HTTPStatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// retryBackoff computes the delay before the given zero-based retry attempt,
+// honoring a server supplied Retry-After value when present and otherwise
+// falling back to exponential backoff plus a random jitter of up to
+// BaseDelay, with the sum capped at MaxDelay.
+func retryBackoff(attempt int, retryAfter string, policy RetryPolicy) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter); ok {
+		return d
+	}
+
+	base := policy.BaseDelay
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	maxDelay := policy.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	backoff := base * time.Duration(1<<uint(attempt))
+	if backoff > maxDelay || backoff <= 0 {
+		backoff = maxDelay
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	delay := backoff + jitter
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter parses the Retry-After header value, which per RFC 7231
+// is either an integer number of seconds or an HTTP-date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,151 @@
+package openai
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		policy RetryPolicy
+		want   bool
+	}{
+		{"server_error", &APIError{Type: "server_error"}, RetryPolicy{}, true},
+		{"rate_limit_exceeded", &APIError{Type: "rate_limit_exceeded"}, RetryPolicy{}, true},
+		{"invalid_request_error", &APIError{Type: "invalid_request_error"}, RetryPolicy{}, false},
+		{"insufficient_quota default", &APIError{Type: "insufficient_quota"}, RetryPolicy{}, false},
+		{"insufficient_quota opted in", &APIError{Type: "insufficient_quota"}, RetryPolicy{RetryQuotaErrors: true}, true},
+		{"status based 500", &APIError{HTTPStatusCode: http.StatusInternalServerError}, RetryPolicy{}, true},
+		{"status based 400", &APIError{HTTPStatusCode: http.StatusBadRequest}, RetryPolicy{}, false},
+		{"request error 503", &RequestError{HTTPStatusCode: http.StatusServiceUnavailable}, RetryPolicy{}, true},
+		{"unrelated error", errors.New("boom"), RetryPolicy{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := shouldRetry(tc.err, tc.policy); got != tc.want {
+				t.Errorf("shouldRetry(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoffHonorsRetryAfter(t *testing.T) {
+	delay := retryBackoff(0, "2", RetryPolicy{})
+	if delay != 2*time.Second {
+		t.Errorf("retryBackoff with Retry-After=2 = %v, want 2s", delay)
+	}
+}
+
+func TestRetryBackoffCapsExponentialGrowth(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := retryBackoff(attempt, "", policy)
+		if delay > policy.MaxDelay {
+			t.Errorf("retryBackoff(%d) = %v, want <= %v", attempt, delay, policy.MaxDelay)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("5"); !ok || d != 5*time.Second {
+		t.Errorf("parseRetryAfter(\"5\") = %v, %v, want 5s, true", d, ok)
+	}
+
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	d, ok := parseRetryAfter(future)
+	if !ok || d <= 0 || d > 11*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, %v, want ~10s, true", future, d, ok)
+	}
+
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("parseRetryAfter(\"\") should report ok=false")
+	}
+
+	if _, ok := parseRetryAfter("not-a-date"); ok {
+		t.Error("parseRetryAfter(\"not-a-date\") should report ok=false")
+	}
+}
+
+func TestSendRequestRetriesThenSucceeds(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":{"message":"overloaded","type":"server_error"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var retries []int
+	config := DefaultConfig("test-token")
+	config.BaseURL = server.URL
+	config.RetryPolicy = RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   time.Millisecond,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			retries = append(retries, attempt)
+		},
+	}
+	client := NewClientWithConfig(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if err := client.sendRequest(req, &out); err != nil {
+		t.Fatalf("sendRequest: %v", err)
+	}
+	if !out.OK {
+		t.Error("expected decoded response OK=true")
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if len(retries) != 2 {
+		t.Errorf("OnRetry calls = %d, want 2", len(retries))
+	}
+}
+
+func TestSendRequestStopsOnNonRetryableError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"bad param","type":"invalid_request_error"}}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig("test-token")
+	config.BaseURL = server.URL
+	config.RetryPolicy = RetryPolicy{MaxRetries: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	client := NewClientWithConfig(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	err = client.sendRequest(req, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (invalid_request_error must not retry)", attempts)
+	}
+}